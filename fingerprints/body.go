@@ -0,0 +1,37 @@
+package fingerprints
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Body returns the MD5 and SHA256 hex digests of a normalized response body,
+// for correlating identical assets served from different hosts/paths.
+func Body(body []byte) (md5Hex string, sha256Hex string) {
+	normalized := normalize(body)
+
+	sum := md5.Sum(normalized)
+	shaSum := sha256.Sum256(normalized)
+
+	return hex.EncodeToString(sum[:]), hex.EncodeToString(shaSum[:])
+}
+
+// normalize trims the leading/trailing whitespace most template engines
+// pad responses with, so two otherwise-identical pages don't hash
+// differently over incidental whitespace.
+func normalize(body []byte) []byte {
+	start, end := 0, len(body)
+	for start < end && isSpace(body[start]) {
+		start++
+	}
+	for end > start && isSpace(body[end-1]) {
+		end--
+	}
+
+	return body[start:end]
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
@@ -0,0 +1,69 @@
+// Package fingerprints wraps the asset-correlation signals extractify can
+// compute for an HTTP target: a Shodan-compatible favicon hash, response
+// body hashes, and a lightweight TLS fingerprint, so library consumers can
+// call them directly instead of going through the CLI.
+package fingerprints
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Favicon fetches baseURL's favicon.ico and returns its Shodan-compatible
+// MurmurHash3 int32 hash: the favicon bytes are base64-encoded with
+// standard 76-column line wrapping (including a trailing newline, matching
+// Python's base64.encodebytes) before being hashed, which is what makes the
+// result comparable against Shodan's own `http.favicon.hash` index.
+// favicon.ico is requested from baseURL's origin rather than its exact path,
+// since that is where browsers and Shodan itself look for it regardless of
+// which page on the site baseURL points at.
+func Favicon(client *resty.Client, baseURL string) (int32, error) {
+	origin, err := faviconOrigin(baseURL)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := client.R().Get(origin)
+	if err != nil {
+		return 0, err
+	}
+	if resp.IsError() {
+		return 0, fmt.Errorf("favicon request to %s returned %s", origin, resp.Status())
+	}
+
+	encoded := wrap76(base64.StdEncoding.EncodeToString(resp.Body()))
+
+	return int32(hash32([]byte(encoded), 0)), nil
+}
+
+// faviconOrigin derives "scheme://host/favicon.ico" from baseURL, discarding
+// its path so a page deep in a site (or one discovered by -crawl) still
+// fetches the site's real favicon instead of a 404 under that page's path.
+func faviconOrigin(baseURL string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	return u.Scheme + "://" + u.Host + "/favicon.ico", nil
+}
+
+// wrap76 reproduces Python's base64.encodebytes line wrapping: a newline
+// every 76 characters, plus one trailing newline.
+func wrap76(encoded string) string {
+	var b bytes.Buffer
+
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76])
+		b.WriteByte('\n')
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded)
+	b.WriteByte('\n')
+
+	return b.String()
+}
@@ -0,0 +1,30 @@
+package fingerprints
+
+import "testing"
+
+// Known-answer vectors for the 32-bit x86 variant of MurmurHash3, matching
+// the values produced by reference implementations (e.g. spaolacci/murmur3)
+// for the same (data, seed) pairs.
+func TestHash32KnownVectors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		seed uint32
+		want uint32
+	}{
+		{"empty", "", 0, 0x00000000},
+		{"test, seed 0", "test", 0, 0xba6bd213},
+		{"hello world, seed 0", "Hello, world!", 0, 0xc0363e43},
+		{"hello world, seed 1", "Hello, world!", 1, 0xaa5dc85b},
+		{"pangram, seed 0", "The quick brown fox jumps over the lazy dog", 0, 0x2e4ff723},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hash32([]byte(tt.data), tt.seed)
+			if got != tt.want {
+				t.Errorf("hash32(%q, %d) = 0x%08x, want 0x%08x", tt.data, tt.seed, got, tt.want)
+			}
+		})
+	}
+}
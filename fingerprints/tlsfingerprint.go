@@ -0,0 +1,42 @@
+package fingerprints
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"time"
+)
+
+// TLSFingerprint returns a lightweight TLS fingerprint of host:port, built
+// from the negotiated protocol version, cipher suite and ALPN across a
+// single handshake.
+//
+// This is deliberately not JARM: real JARM sends ten crafted ClientHellos
+// with deliberately varied cipher/extension orderings and hashes the
+// server's responses to them, producing a fingerprint comparable against
+// other JARM implementations (Shodan et al.) — crypto/tls doesn't expose the
+// raw control needed for that, and this single-handshake hash isn't
+// comparable against any other tool's output. It's a best-effort
+// approximation good enough to group servers running the same TLS
+// stack/config, which is what -ef mainly needs this for.
+func TLSFingerprint(host string, timeout time.Duration) (string, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+
+	conn, err := tls.DialWithDialer(dialer, "tcp", host, &tls.Config{
+		InsecureSkipVerify: true,
+		MinVersion:         tls.VersionTLS10,
+	})
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	state := conn.ConnectionState()
+	material := fmt.Sprintf("%d|%d|%s", state.Version, state.CipherSuite, state.NegotiatedProtocol)
+
+	sum := sha256.Sum256([]byte(material))
+
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -2,7 +2,12 @@ package main
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"github.com/SharokhAtaie/extractify/output"
+	"github.com/SharokhAtaie/extractify/pkg/crawler"
+	"github.com/SharokhAtaie/extractify/pkg/netpolicy"
+	"github.com/SharokhAtaie/extractify/pkg/runner"
 	"github.com/SharokhAtaie/extractify/scanner"
 	"github.com/go-resty/resty/v2"
 	"github.com/projectdiscovery/goflags"
@@ -12,22 +17,48 @@ import (
 	urlutil "github.com/projectdiscovery/utils/url"
 	"io"
 	"log"
+	"net"
+	"net/http"
 	"os"
 	"strings"
 	"time"
 )
 
 type options struct {
-	file      string
-	url       string
-	list      string
-	endpoint  bool
-	secret    bool
-	parameter bool
-	all       bool
-	urls      bool
-	header    string
-	verbose   bool
+	file               string
+	url                string
+	list               string
+	endpoint           bool
+	secret             bool
+	parameter          bool
+	all                bool
+	urls               bool
+	header             goflags.StringSlice
+	verbose            bool
+	threads            int
+	rateLimit          int
+	timeout            int
+	retries            int
+	outFile            string
+	json               bool
+	jsonl              bool
+	csv                bool
+	customExtract      goflags.StringSlice
+	crawl              bool
+	depth              int
+	maxPages           int
+	scope              string
+	scopeMode          string
+	filterErrorPages   bool
+	errorPageScore     int
+	errorPageWhitelist goflags.StringSlice
+	fingerprint        bool
+	tlsFingerprint     bool
+	proxy              string
+	caFile             string
+	denyCIDR           goflags.StringSlice
+	allowCIDR          goflags.StringSlice
+	denyInternal       bool
 }
 
 func main() {
@@ -47,14 +78,59 @@ func main() {
 		flagSet.BoolVarP(&opt.urls, "urls", "eu", false, "Extract urls"),
 		flagSet.BoolVarP(&opt.parameter, "parameters", "ep", false, "Extract parameters"),
 		flagSet.BoolVarP(&opt.secret, "secrets", "es", false, "Extract secrets"),
+		flagSet.BoolVarP(&opt.fingerprint, "fingerprint", "ef", false, "Extract favicon/body/TLS fingerprints"),
 		flagSet.BoolVarP(&opt.all, "all", "ea", false, "Extract all"),
 	)
 
 	flagSet.CreateGroup("Others", "Others",
-		flagSet.StringVarP(&opt.header, "header", "H", "", "Set custom header"),
+		flagSet.StringSliceVarP(&opt.header, "header", "H", nil, "Set custom header (-H can be repeated)", goflags.StringSliceOptions),
 		flagSet.BoolVarP(&opt.verbose, "verbose", "v", false, "Verbose mode"),
 	)
 
+	flagSet.CreateGroup("Rate-Limit", "Rate-Limit",
+		flagSet.IntVarP(&opt.threads, "threads", "c", 25, "Number of concurrent workers"),
+		flagSet.IntVarP(&opt.rateLimit, "rate-limit", "rl", 0, "Maximum requests per second (0 = unlimited)"),
+		flagSet.IntVarP(&opt.timeout, "timeout", "timeout", 2, "Time to wait in seconds before request timeout"),
+		flagSet.IntVarP(&opt.retries, "retries", "retries", 0, "Number of retries for failed requests"),
+	)
+
+	flagSet.CreateGroup("Output", "Output",
+		flagSet.StringVarP(&opt.outFile, "output", "o", "", "File to write output to (defaults to stdout)"),
+		flagSet.BoolVarP(&opt.json, "json", "json", false, "Write output as a single JSON array"),
+		flagSet.BoolVarP(&opt.jsonl, "jsonl", "jsonl", false, "Write output as newline-delimited JSON"),
+		flagSet.BoolVarP(&opt.csv, "csv", "csv", false, "Write output as CSV"),
+	)
+
+	flagSet.CreateGroup("Custom-Extract", "Custom-Extract",
+		flagSet.StringSliceVarP(&opt.customExtract, "custom-extract", "ce", nil, "YAML file of custom regex extraction rules (-ce can be repeated)", goflags.FileCommaSeparatedStringSliceOptions),
+	)
+
+	flagSet.CreateGroup("Crawl", "Crawl",
+		flagSet.BoolVarP(&opt.crawl, "crawl", "crawl", false, "Follow extracted endpoints and recursively scan them"),
+		flagSet.IntVarP(&opt.depth, "depth", "depth", 2, "Maximum crawl depth"),
+		flagSet.IntVarP(&opt.maxPages, "max-pages", "mp", 0, "Maximum number of pages to crawl (0 = unlimited)"),
+		flagSet.StringVarP(&opt.scope, "scope", "scope", "", "Regex restricting which hosts are crawled (used with -scope-mode regex)"),
+		flagSet.StringVarP(&opt.scopeMode, "scope-mode", "sm", crawler.ScopeSubdomains, "Crawl scope: subs (same registered domain) or regex"),
+	)
+
+	flagSet.CreateGroup("Error-Page", "Error-Page",
+		flagSet.BoolVarP(&opt.filterErrorPages, "filter-error-pages", "fep", false, "Skip extraction on responses classified as soft-404/error pages"),
+		flagSet.IntVarP(&opt.errorPageScore, "error-page-score", "eps", 3, "Score at or above which a response is classified as an error page"),
+		flagSet.StringSliceVarP(&opt.errorPageWhitelist, "error-page-whitelist", "epw", nil, "File of known-good MD5 body fingerprints to never classify as error pages", goflags.FileCommaSeparatedStringSliceOptions),
+	)
+
+	flagSet.CreateGroup("Fingerprint", "Fingerprint",
+		flagSet.BoolVarP(&opt.tlsFingerprint, "tls-fingerprint", "tlsfp", false, "Include a lightweight TLS fingerprint of the origin (used with -ef); not JARM, not comparable with other tools' JARM output"),
+	)
+
+	flagSet.CreateGroup("Network", "Network",
+		flagSet.StringVarP(&opt.proxy, "proxy", "proxy", "", "HTTP/SOCKS5 proxy URL"),
+		flagSet.StringVarP(&opt.caFile, "ca", "ca", "", "Custom CA certificate file to trust (disables the default insecure TLS mode)"),
+		flagSet.StringSliceVarP(&opt.denyCIDR, "deny-cidr", "dc", nil, "CIDR/IP to deny connections to (repeatable)", goflags.StringSliceOptions),
+		flagSet.StringSliceVarP(&opt.allowCIDR, "allow-cidr", "ac", nil, "CIDR/IP to allow connections to, all else denied (repeatable)", goflags.StringSliceOptions),
+		flagSet.BoolVarP(&opt.denyInternal, "deny-internal", "di", false, "Deny connections to RFC1918/loopback/link-local ranges (covers cloud metadata IPs)"),
+	)
+
 	if err := flagSet.Parse(); err != nil {
 		log.Fatalf("Could not parse flags: %s\n", err)
 	}
@@ -64,6 +140,23 @@ func main() {
 		return
 	}
 
+	var writer output.Writer
+	if usesStructuredOutput(opt) {
+		var err error
+		writer, err = newWriter(opt)
+		if err != nil {
+			gologger.Fatal().Msgf("failed to set up output: %v", err)
+		}
+		defer writer.Close()
+	}
+
+	customRules, err := scanner.LoadCustomRules([]string(opt.customExtract))
+	if err != nil {
+		gologger.Fatal().Msgf("failed to load custom rules: %v", err)
+	}
+
+	errorPageClassifier := scanner.NewErrorPageClassifier([]string(opt.errorPageWhitelist))
+
 	if opt.file != "" {
 		bin, err := os.ReadFile(opt.file)
 		if err != nil {
@@ -71,9 +164,16 @@ func main() {
 		}
 
 		gologger.Info().Msgf("Processing %s", opt.file)
-		secrets, urls, endpoints, parameters := Run(bin, opt.file)
+		secrets, urls, endpoints, parameters := Run(bin, opt.file, nil, customRules)
 
-		HandleResults(opt.endpoint, opt.parameter, opt.urls, opt.secret, opt.all, secrets, urls, endpoints, parameters)
+		if usesStructuredOutput(opt) {
+			records := output.BuildRecords(opt.file, secrets, urls, endpoints, parameters)
+			if err := WriteRecords(writer, opt, records); err != nil {
+				gologger.Error().Msgf("failed to write output: %v", err)
+			}
+		} else {
+			HandleResults(opt.endpoint, opt.parameter, opt.urls, opt.secret, opt.fingerprint, opt.all, secrets, urls, endpoints, parameters, nil)
+		}
 		return
 	}
 
@@ -98,27 +198,233 @@ func main() {
 		URLs = strings.Fields(string(bin))
 	}
 
-	for _, url := range URLs {
+	runnerOpts := &runner.Options{
+		Threads:   opt.threads,
+		RateLimit: opt.rateLimit,
+		Timeout:   time.Duration(opt.timeout) * time.Second,
+		Retries:   opt.retries,
+	}
 
-		Data, err := Request(url, opt.header, opt.verbose)
+	tlsConfig, err := buildTLSConfig(opt.caFile)
+	if err != nil {
+		gologger.Fatal().Msgf("failed to load -ca: %v", err)
+	}
+
+	if opt.proxy != "" && (len(opt.denyCIDR) > 0 || len(opt.allowCIDR) > 0 || opt.denyInternal) {
+		gologger.Fatal().Msgf("-proxy cannot be combined with -deny-cidr/-allow-cidr/-deny-internal: the policy only sees the proxy's address, not the real target, so it would silently stop protecting anything")
+	}
+
+	policy, err := netpolicy.New([]string(opt.allowCIDR), []string(opt.denyCIDR), opt.denyInternal)
+	if err != nil {
+		gologger.Fatal().Msgf("invalid network policy: %v", err)
+	}
+
+	transport := &http.Transport{
+		DialContext: policy.DialContext(&net.Dialer{Timeout: time.Duration(opt.timeout) * time.Second}),
+	}
+
+	client := resty.New().
+		SetTransport(transport).
+		SetHeader("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:120.0) Firefox/120.0").
+		SetHeader("Accept", "*/*").
+		SetTLSClientConfig(tlsConfig).
+		SetRedirectPolicy(resty.FlexibleRedirectPolicy(3))
+
+	if opt.proxy != "" {
+		client.SetProxy(opt.proxy)
+	}
+
+	if opt.verbose {
+		client.SetDebug(true)
+	}
+
+	pool := runner.New(runnerOpts, client)
+
+	var crawl *crawler.Crawler
+	if opt.crawl {
+		crawl, err = crawler.New(&crawler.Options{
+			MaxDepth:  opt.depth,
+			MaxPages:  opt.maxPages,
+			ScopeMode: opt.scopeMode,
+			ScopeExpr: opt.scope,
+		})
 		if err != nil {
-			gologger.Error().Msgf("%s [%s]\n\n", err, url)
-			continue
+			gologger.Fatal().Msgf("invalid -scope regex: %v", err)
 		}
-		gologger.Info().Msgf("Processing %s\n\n", url)
+	}
 
-		secrets, urls, endpoints, parameters := Run(Data, url)
+	frontier := URLs
+
+	for page := 0; ; page++ {
+		var nextFrontier []string
+
+		pool.Run(frontier, func(client *resty.Client, url string) ([]byte, http.Header, any, error) {
+			body, headers, err := RequestWithClient(client, url, []string(opt.header), opt.verbose)
+			if err != nil {
+				return body, headers, nil, err
+			}
+
+			// Fingerprinting does its own network I/O (a favicon GET, plus
+			// an optional TLS handshake for -tls-fingerprint), so it runs here inside
+			// the Fetcher rather than in handle below: Pool.Run calls handle
+			// under a mutex, and doing this slow work there would serialize
+			// every worker's fingerprinting regardless of -threads.
+			var extra any
+			if (opt.fingerprint || opt.all) && !(opt.filterErrorPages && errorPageClassifier.IsErrorPage(body, opt.errorPageScore)) {
+				fp := scanner.BuildFingerprint(client, url, body, opt.tlsFingerprint)
+				extra = &fp
+			}
+
+			return body, headers, extra, nil
+		}, func(result runner.Result) {
+			if result.Err != nil {
+				return
+			}
+			gologger.Info().Msgf("Processing %s\n\n", result.URL)
+
+			if opt.filterErrorPages && errorPageClassifier.IsErrorPage(result.Data, opt.errorPageScore) {
+				gologger.Info().Msgf("Skipping %s, classified as an error page\n\n", result.URL)
+				return
+			}
+
+			secrets, urls, endpoints, parameters := Run(result.Data, result.URL, result.Headers, customRules)
+
+			fp, _ := result.Extra.(*scanner.Fingerprint)
+
+			if usesStructuredOutput(opt) {
+				records := output.BuildRecords(result.URL, secrets, urls, endpoints, parameters)
+				if fp != nil {
+					records = append(records, output.BuildFingerprintRecord(result.URL, *fp))
+				}
+				if err := WriteRecords(writer, opt, records); err != nil {
+					gologger.Error().Msgf("failed to write output: %v", err)
+				}
+			} else {
+				HandleResults(opt.endpoint, opt.parameter, opt.urls, opt.secret, opt.fingerprint, opt.all, secrets, urls, endpoints, parameters, fp)
+			}
+
+			if crawl == nil {
+				return
+			}
+
+			base, err := ParseURL(result.URL)
+			if err != nil {
+				return
+			}
+
+			// nextFrontier is only ever appended to from inside pool.Run's
+			// handler, which already runs under the pool's own mutex.
+			nextFrontier = append(nextFrontier, crawl.Discover(base, result.Data, append(urls, endpoints...))...)
+		})
+
+		if crawl == nil || page >= opt.depth || len(nextFrontier) == 0 {
+			break
+		}
 
-		HandleResults(opt.endpoint, opt.parameter, opt.urls, opt.secret, opt.all, secrets, urls, endpoints, parameters)
+		frontier = nextFrontier
 	}
 }
 
-func Run(Data []byte, Source string) ([]scanner.SecretMatched, []string, []string, []string) {
+// buildTLSConfig returns the blanket-insecure default extractify has always
+// used, unless caFile is set: a custom CA means the caller cares about
+// verifying who they're talking to, so skip-verify is turned off and the CA
+// is the only one trusted.
+func buildTLSConfig(caFile string) (*tls.Config, error) {
+	if caFile == "" {
+		return &tls.Config{InsecureSkipVerify: true}, nil
+	}
+
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", caFile)
+	}
+
+	return &tls.Config{RootCAs: pool}, nil
+}
+
+// usesStructuredOutput reports whether the user asked for a machine-readable
+// format instead of the default pretty-printed text.
+func usesStructuredOutput(opt *options) bool {
+	return opt.outFile != "" || opt.json || opt.jsonl || opt.csv
+}
+
+// newWriter builds the output.Writer matching -o/-json/-jsonl/-csv. Absent
+// -json/-jsonl/-csv, it falls back to a TextWriter over the -o file (or
+// stdout when -o is unset).
+func newWriter(opt *options) (output.Writer, error) {
+	var w io.WriteCloser = os.Stdout
+	if opt.outFile != "" {
+		f, err := os.Create(opt.outFile)
+		if err != nil {
+			return nil, err
+		}
+		w = f
+	}
+
+	switch {
+	case opt.json:
+		return output.NewJSONWriter(w), nil
+	case opt.jsonl:
+		return output.NewJSONLWriter(w), nil
+	case opt.csv:
+		return output.NewCSVWriter(w)
+	default:
+		return output.NewTextWriter(w), nil
+	}
+}
+
+// selectedTypes mirrors HandleResults' flag priority (endpoint > parameter >
+// url > secret > fingerprint > all > default-to-secret) but as a set of record types, so a
+// single pass over BuildRecords' output can filter for Writers.
+func selectedTypes(opt *options) map[string]bool {
+	switch {
+	case opt.endpoint:
+		return map[string]bool{output.TypeEndpoint: true}
+	case opt.parameter:
+		return map[string]bool{output.TypeParameter: true}
+	case opt.urls:
+		return map[string]bool{output.TypeURL: true}
+	case opt.secret:
+		return map[string]bool{output.TypeSecret: true}
+	case opt.fingerprint:
+		return map[string]bool{output.TypeFingerprint: true}
+	case opt.all:
+		return map[string]bool{output.TypeSecret: true, output.TypeURL: true, output.TypeEndpoint: true, output.TypeParameter: true, output.TypeFingerprint: true}
+	default:
+		return map[string]bool{output.TypeSecret: true}
+	}
+}
+
+// WriteRecords writes every record whose type was selected via the
+// extraction flags to w, in encounter order.
+func WriteRecords(w output.Writer, opt *options, records []output.Record) error {
+	types := selectedTypes(opt)
+	for _, r := range records {
+		if !types[r.Type] {
+			continue
+		}
+		if err := w.Write(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func Run(Data []byte, Source string, Headers http.Header, customRules []scanner.CustomRule) ([]scanner.SecretMatched, []string, []string, []string) {
 	var sortedUrls []string
 	var sortedEndpoints []string
 
 	SecretMatchResult := scanner.SecretsMatch(Source, Data)
 
+	if len(customRules) > 0 {
+		SecretMatchResult = append(SecretMatchResult, scanner.CustomMatch(Source, Data, serializeHeaders(Headers), customRules)...)
+	}
+
 	EndpointMatchResult := scanner.EndpointsMatch(Data)
 
 	for _, v := range EndpointMatchResult {
@@ -135,7 +441,7 @@ func Run(Data []byte, Source string) ([]scanner.SecretMatched, []string, []strin
 	return SecretMatchResult, sortedUrls, sortedEndpoints, sUtils.Dedupe(ParameterMatchResults)
 }
 
-func HandleResults(endpoint, parameter, url, secret, all bool, secrets []scanner.SecretMatched, urls, endpoints, parameters []string) {
+func HandleResults(endpoint, parameter, url, secret, fingerprint, all bool, secrets []scanner.SecretMatched, urls, endpoints, parameters []string, fp *scanner.Fingerprint) {
 	if endpoint {
 		HandleEndpoint(endpoints)
 	} else if parameter {
@@ -144,11 +450,14 @@ func HandleResults(endpoint, parameter, url, secret, all bool, secrets []scanner
 		HandleURL(urls)
 	} else if secret {
 		HandleSecret(secrets)
+	} else if fingerprint {
+		HandleFingerprint(fp)
 	} else if all {
 		HandleSecret(secrets)
 		HandleURL(urls)
 		HandleEndpoint(endpoints)
 		HandleParameter(parameters)
+		HandleFingerprint(fp)
 	} else {
 		HandleSecret(secrets)
 	}
@@ -189,6 +498,20 @@ func HandleURL(urls []string) {
 	}
 }
 
+func HandleFingerprint(fp *scanner.Fingerprint) {
+	if fp == nil {
+		gologger.Info().Msgf("No results for Fingerprint\n\n")
+		return
+	}
+
+	gologger.Info().Msgf("Fingerprint")
+	fmt.Printf("Favicon: %d\nMD5: %s\nSHA256: %s\n", fp.FaviconHash, fp.BodyMD5, fp.BodySHA256)
+	if fp.TLSFingerprint != "" {
+		fmt.Printf("TLS Fingerprint: %s\n", fp.TLSFingerprint)
+	}
+	fmt.Println("")
+}
+
 func HandleParameter(parameters []string) {
 	if len(parameters) > 0 {
 		gologger.Info().Msgf("Parameters")
@@ -209,47 +532,70 @@ func ParseURL(url string) (*urlutil.URL, error) {
 	return urlx, err
 }
 
-func Request(URL string, Header string, Verbose bool) ([]byte, error) {
+func Request(URL string, Headers []string, Verbose bool) ([]byte, http.Header, error) {
+	client := resty.New().
+		SetTimeout(2*time.Second).
+		SetHeader("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:120.0) Firefox/120.0").
+		SetHeader("Accept", "*/*").
+		SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}).
+		SetRedirectPolicy(resty.FlexibleRedirectPolicy(3))
+
+	if Verbose {
+		client.SetDebug(true)
+	}
 
+	return RequestWithClient(client, URL, Headers, Verbose)
+}
+
+// RequestWithClient performs the same request as Request but reuses a
+// pre-built client, which is what the runner pool hands in so every worker
+// shares a single connection pool, transport and timeout/retry configuration.
+// Per-target values (Origin, custom headers) are set on the request object
+// rather than the client, since the client is shared across goroutines.
+func RequestWithClient(client *resty.Client, URL string, Headers []string, Verbose bool) ([]byte, http.Header, error) {
 	u, _ := ParseURL(URL)
 
 	if u.Host == "" {
-		return nil, fmt.Errorf("%s", "Domain is not valid")
+		return nil, nil, fmt.Errorf("%s", "Domain is not valid")
 	}
 
 	if u.Scheme == "" {
 		URL = "https://" + u.Host
 	}
 
-	client := resty.New().
-		SetTimeout(2*time.Second).
-		SetHeader("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:120.0) Firefox/120.0").
-		SetHeader("Accept", "*/*").
-		SetHeader("Origin", u.Scheme+"://"+u.Host).
-		SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}).
-		SetRedirectPolicy(resty.FlexibleRedirectPolicy(3))
+	req := client.R().SetHeader("Origin", u.Scheme+"://"+u.Host)
 
-	if Header != "" {
-		headers := strings.Split(Header, ":")
-		if len(headers) == 2 {
-			client.SetHeader(headers[0], strings.TrimSpace(headers[1]))
-		} else {
+	for _, header := range Headers {
+		parts := strings.SplitN(header, ":", 2)
+		if len(parts) != 2 {
 			gologger.Fatal().Msgf("Custom header is not valid. Example (\"X-header: Value\")")
+			continue
 		}
+		req.SetHeader(parts[0], strings.TrimSpace(parts[1]))
 	}
 
-	if Verbose {
-		client.SetDebug(true)
+	resp, err := req.Get(URL)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	resp, err := client.R().
-		Get(URL)
+	return resp.Body(), resp.Header(), nil
+}
 
-	if err != nil {
-		return nil, err
+// serializeHeaders flattens response headers into "Key: Value" lines, one
+// per header/value pair, so custom-extract rules with part: header or
+// part: all can regex-match against them the same way they match the body.
+func serializeHeaders(h http.Header) string {
+	var b strings.Builder
+	for key, values := range h {
+		for _, v := range values {
+			b.WriteString(key)
+			b.WriteString(": ")
+			b.WriteString(v)
+			b.WriteByte('\n')
+		}
 	}
-
-	return resp.Body(), nil
+	return b.String()
 }
 
 func PrintUsage() {
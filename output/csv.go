@@ -0,0 +1,44 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+var csvHeader = []string{"source_url", "type", "name", "match", "severity", "line_number", "context_snippet"}
+
+// CSVWriter writes Records as CSV rows, with a header written up-front.
+type CSVWriter struct {
+	w   io.WriteCloser
+	enc *csv.Writer
+}
+
+func NewCSVWriter(w io.WriteCloser) (*CSVWriter, error) {
+	enc := csv.NewWriter(w)
+	if err := enc.Write(csvHeader); err != nil {
+		return nil, err
+	}
+
+	return &CSVWriter{w: w, enc: enc}, nil
+}
+
+func (c *CSVWriter) Write(r Record) error {
+	return c.enc.Write([]string{
+		r.SourceURL,
+		r.Type,
+		r.Name,
+		r.Match,
+		r.Severity,
+		strconv.Itoa(r.LineNumber),
+		r.ContextSnippet,
+	})
+}
+
+func (c *CSVWriter) Close() error {
+	c.enc.Flush()
+	if err := c.enc.Error(); err != nil {
+		return err
+	}
+	return c.w.Close()
+}
@@ -0,0 +1,48 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONLWriter writes one JSON object per Record, newline-delimited.
+type JSONLWriter struct {
+	enc *json.Encoder
+	w   io.WriteCloser
+}
+
+func NewJSONLWriter(w io.WriteCloser) *JSONLWriter {
+	return &JSONLWriter{enc: json.NewEncoder(w), w: w}
+}
+
+func (j *JSONLWriter) Write(r Record) error {
+	return j.enc.Encode(r)
+}
+
+func (j *JSONLWriter) Close() error {
+	return j.w.Close()
+}
+
+// JSONWriter writes every Record as a single JSON array, for -json.
+type JSONWriter struct {
+	w       io.WriteCloser
+	records []Record
+}
+
+func NewJSONWriter(w io.WriteCloser) *JSONWriter {
+	return &JSONWriter{w: w}
+}
+
+func (j *JSONWriter) Write(r Record) error {
+	j.records = append(j.records, r)
+	return nil
+}
+
+func (j *JSONWriter) Close() error {
+	enc := json.NewEncoder(j.w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(j.records); err != nil {
+		return err
+	}
+	return j.w.Close()
+}
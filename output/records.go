@@ -0,0 +1,53 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/SharokhAtaie/extractify/scanner"
+)
+
+// BuildRecords flattens the heterogeneous results Run produces (secrets,
+// urls, endpoints, parameters) into the single Record shape Writers expect.
+func BuildRecords(source string, secrets []scanner.SecretMatched, urls, endpoints, parameters []string) []Record {
+	var records []Record
+
+	for _, s := range secrets {
+		records = append(records, Record{
+			SourceURL:      source,
+			Type:           TypeSecret,
+			Name:           s.Secret.Name,
+			Match:          s.Match,
+			Severity:       s.Severity,
+			LineNumber:     s.Line,
+			ContextSnippet: s.Context,
+		})
+	}
+
+	for _, u := range urls {
+		records = append(records, Record{SourceURL: source, Type: TypeURL, Match: u})
+	}
+
+	for _, e := range endpoints {
+		records = append(records, Record{SourceURL: source, Type: TypeEndpoint, Match: e})
+	}
+
+	for _, p := range parameters {
+		records = append(records, Record{SourceURL: source, Type: TypeParameter, Match: p})
+	}
+
+	return records
+}
+
+// BuildFingerprintRecord flattens a scanner.Fingerprint into the same
+// Record shape every other extraction category uses, with Name "favicon",
+// Match carrying the favicon hash, and ContextSnippet listing the body MD5,
+// body SHA256 and TLS fingerprint as key=value pairs.
+func BuildFingerprintRecord(source string, fp scanner.Fingerprint) Record {
+	return Record{
+		SourceURL:      source,
+		Type:           TypeFingerprint,
+		Name:           "favicon",
+		Match:          fmt.Sprintf("%d", fp.FaviconHash),
+		ContextSnippet: fmt.Sprintf("md5=%s sha256=%s tls_fingerprint=%s", fp.BodyMD5, fp.BodySHA256, fp.TLSFingerprint),
+	}
+}
@@ -0,0 +1,30 @@
+package output
+
+import (
+	"fmt"
+	"io"
+)
+
+// TextWriter reproduces extractify's original pretty-printed stdout format,
+// now backed by the Writer interface so it can target a file as well.
+type TextWriter struct {
+	w io.WriteCloser
+}
+
+func NewTextWriter(w io.WriteCloser) *TextWriter {
+	return &TextWriter{w: w}
+}
+
+func (t *TextWriter) Write(r Record) error {
+	if r.Name != "" {
+		_, err := fmt.Fprintf(t.w, "Name: %s\nMatch: %s\n\n", r.Name, r.Match)
+		return err
+	}
+
+	_, err := fmt.Fprintf(t.w, "%s\n", r.Match)
+	return err
+}
+
+func (t *TextWriter) Close() error {
+	return t.w.Close()
+}
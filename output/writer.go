@@ -0,0 +1,31 @@
+// Package output formats the unified records extractify produces (secrets,
+// endpoints, urls, parameters) for consumption outside of the terminal.
+package output
+
+// Record is the common shape every finding is normalized into before it
+// reaches a Writer, regardless of which extractor produced it.
+type Record struct {
+	SourceURL      string `json:"source_url"`
+	Type           string `json:"type"`
+	Name           string `json:"name,omitempty"`
+	Match          string `json:"match"`
+	Severity       string `json:"severity,omitempty"`
+	LineNumber     int    `json:"line_number,omitempty"`
+	ContextSnippet string `json:"context_snippet,omitempty"`
+}
+
+// Record types, mirroring the -ee/-eu/-ep/-es/-ef extraction flags.
+const (
+	TypeSecret      = "secret"
+	TypeEndpoint    = "endpoint"
+	TypeURL         = "url"
+	TypeParameter   = "param"
+	TypeFingerprint = "fingerprint"
+)
+
+// Writer emits Records in whatever format it implements. Close flushes and
+// releases any underlying file handle.
+type Writer interface {
+	Write(Record) error
+	Close() error
+}
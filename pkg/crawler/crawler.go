@@ -0,0 +1,154 @@
+// Package crawler resolves the endpoints extractify's scanner finds on a
+// page back into absolute URLs, filters them to the configured scope, and
+// hands back the ones worth fetching next so main can feed them back into
+// the runner pool for another depth.
+package crawler
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	urlutil "github.com/projectdiscovery/utils/url"
+)
+
+// Scope modes for the -scope-mode flag.
+const (
+	ScopeSubdomains = "subs"
+	ScopeRegex      = "regex"
+)
+
+// Options controls how far and how wide a -crawl run is allowed to go.
+type Options struct {
+	MaxDepth  int
+	MaxPages  int
+	ScopeMode string
+	ScopeExpr string
+}
+
+var (
+	scriptSrcRegex = regexp.MustCompile(`<script[^>]+src=["']([^"']+)["']`)
+	linkHrefRegex  = regexp.MustCompile(`<link[^>]+href=["']([^"']+)["']`)
+	sourceMapRegex = regexp.MustCompile(`//[#@]\s*sourceMappingURL=([^\s]+)`)
+)
+
+// Crawler tracks crawl-wide state (the seen-set and page budget) across
+// every worker and every depth of a -crawl run.
+type Crawler struct {
+	opts      *Options
+	scopeRe   *regexp.Regexp
+	seen      sync.Map
+	pageCount int64
+}
+
+// New builds a Crawler for opts. When ScopeMode is ScopeRegex, ScopeExpr is
+// compiled once up front.
+func New(opts *Options) (*Crawler, error) {
+	c := &Crawler{opts: opts}
+
+	if opts.ScopeMode == ScopeRegex && opts.ScopeExpr != "" {
+		re, err := regexp.Compile(opts.ScopeExpr)
+		if err != nil {
+			return nil, err
+		}
+		c.scopeRe = re
+	}
+
+	return c, nil
+}
+
+// Discover resolves every endpoint/script/stylesheet/source-map reference in
+// body against base, keeping only in-scope, not-yet-seen URLs, and stopping
+// once MaxPages has been reached.
+func (c *Crawler) Discover(base *urlutil.URL, body []byte, endpoints []string) []string {
+	var next []string
+
+	candidates := append([]string{}, endpoints...)
+	candidates = append(candidates, extractAttr(scriptSrcRegex, body)...)
+	candidates = append(candidates, extractAttr(linkHrefRegex, body)...)
+	candidates = append(candidates, extractAttr(sourceMapRegex, body)...)
+
+	for _, raw := range candidates {
+		resolved, err := resolve(base, raw)
+		if err != nil {
+			continue
+		}
+
+		if !c.inScope(base, resolved) {
+			continue
+		}
+
+		if _, loaded := c.seen.LoadOrStore(resolved.String(), true); loaded {
+			continue
+		}
+
+		if c.opts.MaxPages > 0 && atomic.AddInt64(&c.pageCount, 1) > int64(c.opts.MaxPages) {
+			return next
+		}
+
+		next = append(next, resolved.String())
+	}
+
+	return next
+}
+
+func extractAttr(re *regexp.Regexp, body []byte) []string {
+	var out []string
+	for _, m := range re.FindAllSubmatch(body, -1) {
+		if len(m) < 2 {
+			continue
+		}
+		out = append(out, string(m[1]))
+	}
+	return out
+}
+
+// resolve turns a (possibly relative) reference found on base's page into an
+// absolute *urlutil.URL. It delegates to (*url.URL).ResolveReference for the
+// actual RFC 3986 resolution (including collapsing "." and ".." segments
+// against base's path), rather than string-concatenating the pieces, so
+// page-relative references like "bundle.js" or "sourceMappingURL=foo.js.map"
+// resolve against base's directory instead of its full file path.
+func resolve(base *urlutil.URL, ref string) (*urlutil.URL, error) {
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return urlutil.ParseURL(base.URL.ResolveReference(refURL).String(), true)
+}
+
+// inScope reports whether candidate may be crawled starting from base,
+// according to the configured scope mode. With no scope configured, crawling
+// is restricted to base's exact host.
+func (c *Crawler) inScope(base, candidate *urlutil.URL) bool {
+	switch c.opts.ScopeMode {
+	case ScopeRegex:
+		if c.scopeRe == nil {
+			return candidate.Host == base.Host
+		}
+		return c.scopeRe.MatchString(candidate.Host)
+	case ScopeSubdomains:
+		return sameRegisteredDomain(base.Host, candidate.Host)
+	default:
+		return candidate.Host == base.Host
+	}
+}
+
+// sameRegisteredDomain compares the last two labels of each host (e.g.
+// "example.com"), which is a reasonable approximation of the registered
+// domain without pulling in a public-suffix-list dependency.
+func sameRegisteredDomain(a, b string) bool {
+	return registeredDomain(a) == registeredDomain(b)
+}
+
+func registeredDomain(host string) string {
+	host = strings.ToLower(strings.Split(host, ":")[0])
+	labels := strings.Split(host, ".")
+	if len(labels) <= 2 {
+		return host
+	}
+	return strings.Join(labels[len(labels)-2:], ".")
+}
@@ -0,0 +1,39 @@
+package crawler
+
+import (
+	"testing"
+
+	urlutil "github.com/projectdiscovery/utils/url"
+)
+
+func TestResolve(t *testing.T) {
+	base, err := urlutil.ParseURL("http://example.com/path/to/page.html", true)
+	if err != nil {
+		t.Fatalf("failed to parse base url: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		ref  string
+		want string
+	}{
+		{"page-relative", "bundle.js", "http://example.com/path/to/bundle.js"},
+		{"source-map-relative", "foo.js.map", "http://example.com/path/to/foo.js.map"},
+		{"root-relative", "/static/app.js", "http://example.com/static/app.js"},
+		{"scheme-relative", "//cdn.example.com/lib.js", "http://cdn.example.com/lib.js"},
+		{"absolute", "https://other.example.com/x.js", "https://other.example.com/x.js"},
+		{"parent-relative", "../shared/lib.js", "http://example.com/path/shared/lib.js"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolve(base, tt.ref)
+			if err != nil {
+				t.Fatalf("resolve(%q) returned error: %v", tt.ref, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("resolve(%q) = %q, want %q", tt.ref, got.String(), tt.want)
+			}
+		})
+	}
+}
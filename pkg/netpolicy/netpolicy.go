@@ -0,0 +1,138 @@
+// Package netpolicy restricts which IP addresses extractify is allowed to
+// connect to, so it can be run safely inside enterprise/bug-bounty
+// environments where egress to internal ranges or the cloud metadata IP
+// must be blocked.
+package netpolicy
+
+import (
+	"context"
+	"fmt"
+	"net"
+)
+
+// internalCIDRs are the ranges -deny-internal expands to: RFC1918 private
+// space, loopback, link-local (which covers the 169.254.169.254 cloud
+// metadata endpoint), and their IPv6 equivalents.
+var internalCIDRs = []string{
+	"127.0.0.0/8",
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"169.254.0.0/16",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+}
+
+// Policy evaluates a resolved IP address against configured allow/deny CIDRs.
+// A nil *Policy permits everything, so callers can skip building one when no
+// restrictions were requested.
+type Policy struct {
+	deny  []*net.IPNet
+	allow []*net.IPNet
+}
+
+// New compiles a Policy from CIDR strings. When denyInternal is set, the
+// RFC1918/loopback/link-local ranges are appended to deny.
+func New(allowCIDRs, denyCIDRs []string, denyInternal bool) (*Policy, error) {
+	if denyInternal {
+		denyCIDRs = append(append([]string{}, denyCIDRs...), internalCIDRs...)
+	}
+
+	p := &Policy{}
+
+	for _, c := range denyCIDRs {
+		n, err := parseCIDR(c)
+		if err != nil {
+			return nil, err
+		}
+		p.deny = append(p.deny, n)
+	}
+
+	for _, c := range allowCIDRs {
+		n, err := parseCIDR(c)
+		if err != nil {
+			return nil, err
+		}
+		p.allow = append(p.allow, n)
+	}
+
+	if len(p.deny) == 0 && len(p.allow) == 0 {
+		return nil, nil
+	}
+
+	return p, nil
+}
+
+func parseCIDR(c string) (*net.IPNet, error) {
+	if _, n, err := net.ParseCIDR(c); err == nil {
+		return n, nil
+	}
+	// Accept a bare IP too, treated as a /32 (or /128 for IPv6).
+	ip := net.ParseIP(c)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid CIDR or IP: %s", c)
+	}
+	bits := 32
+	if ip.To4() == nil {
+		bits = 128
+	}
+	_, n, err := net.ParseCIDR(fmt.Sprintf("%s/%d", ip.String(), bits))
+	return n, err
+}
+
+// Allowed reports whether ip may be dialed. An explicit -allow-cidr list, if
+// present, makes the policy an allowlist: only matching IPs pass. Otherwise
+// every IP is allowed except those matching -deny-cidr/-deny-internal.
+func (p *Policy) Allowed(ip net.IP) bool {
+	if p == nil {
+		return true
+	}
+
+	for _, n := range p.deny {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+
+	if len(p.allow) == 0 {
+		return true
+	}
+
+	for _, n := range p.allow {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DialContext wraps base's DialContext so every connection resolves its
+// address and is checked against the policy before dialing, matching the
+// signature http.Transport.DialContext expects.
+func (p *Policy) DialContext(base *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if p == nil {
+			return base.DialContext(ctx, network, addr)
+		}
+
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ip := range ips {
+			if !p.Allowed(ip) {
+				return nil, fmt.Errorf("netpolicy: connection to %s (%s) is denied", host, ip)
+			}
+		}
+
+		return base.DialContext(ctx, network, net.JoinHostPort(ips[0].String(), port))
+	}
+}
@@ -0,0 +1,88 @@
+package netpolicy
+
+import (
+	"net"
+	"testing"
+)
+
+func TestNewNoRestrictions(t *testing.T) {
+	p, err := New(nil, nil, false)
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+	if p != nil {
+		t.Fatalf("expected a nil Policy when no restrictions are configured, got %+v", p)
+	}
+}
+
+func TestAllowedDenyList(t *testing.T) {
+	p, err := New(nil, []string{"10.0.0.0/8"}, false)
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	if p.Allowed(net.ParseIP("10.1.2.3")) {
+		t.Error("expected 10.1.2.3 to be denied")
+	}
+	if !p.Allowed(net.ParseIP("8.8.8.8")) {
+		t.Error("expected 8.8.8.8 to be allowed")
+	}
+}
+
+func TestAllowedAllowList(t *testing.T) {
+	p, err := New([]string{"93.184.216.0/24"}, nil, false)
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	if !p.Allowed(net.ParseIP("93.184.216.34")) {
+		t.Error("expected an IP inside the allowlist to be allowed")
+	}
+	if p.Allowed(net.ParseIP("8.8.8.8")) {
+		t.Error("expected an IP outside the allowlist to be denied")
+	}
+}
+
+func TestAllowedDenyInternal(t *testing.T) {
+	p, err := New(nil, nil, true)
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	denied := []string{"127.0.0.1", "10.0.0.1", "172.16.0.1", "192.168.1.1", "169.254.169.254", "::1"}
+	for _, ip := range denied {
+		if p.Allowed(net.ParseIP(ip)) {
+			t.Errorf("expected %s to be denied under -deny-internal", ip)
+		}
+	}
+
+	if !p.Allowed(net.ParseIP("8.8.8.8")) {
+		t.Error("expected a public IP to remain allowed under -deny-internal")
+	}
+}
+
+func TestAllowedNilPolicy(t *testing.T) {
+	var p *Policy
+	if !p.Allowed(net.ParseIP("10.0.0.1")) {
+		t.Error("a nil *Policy should allow everything")
+	}
+}
+
+func TestParseCIDRBareIP(t *testing.T) {
+	n, err := parseCIDR("1.2.3.4")
+	if err != nil {
+		t.Fatalf("parseCIDR returned error: %v", err)
+	}
+	if !n.Contains(net.ParseIP("1.2.3.4")) {
+		t.Error("expected the /32 network to contain the exact IP it was built from")
+	}
+	if n.Contains(net.ParseIP("1.2.3.5")) {
+		t.Error("expected the /32 network to exclude a neighboring IP")
+	}
+}
+
+func TestParseCIDRInvalid(t *testing.T) {
+	if _, err := parseCIDR("not-an-ip"); err == nil {
+		t.Error("expected an error for an invalid CIDR/IP")
+	}
+}
@@ -0,0 +1,135 @@
+// Package runner provides a concurrent worker pool for scanning a large
+// list of URLs with a global rate limit, modeled after the runner used by
+// httpx and naabu.
+package runner
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/projectdiscovery/gologger"
+)
+
+// Options controls the concurrency, pacing and per-request behaviour of a Pool.
+type Options struct {
+	Threads   int
+	RateLimit int
+	Timeout   time.Duration
+	Retries   int
+}
+
+// DefaultOptions keeps the 2s timeout extractify shipped with before the
+// worker pool existed, with 25 workers, no rate limit and no retries.
+func DefaultOptions() *Options {
+	return &Options{
+		Threads:   25,
+		RateLimit: 0,
+		Timeout:   2 * time.Second,
+		Retries:   0,
+	}
+}
+
+// Result is the per-target outcome of Fetch, carried back to the caller so it
+// can run Run and hand the results to HandleResults in completion order.
+type Result struct {
+	URL     string
+	Data    []byte
+	Headers http.Header
+	// Extra carries whatever a Fetcher computed alongside the fetch itself
+	// (e.g. a fingerprint), so callers can do expensive per-target work
+	// outside the mutex handle runs under instead of inside it. nil if the
+	// Fetcher didn't set anything.
+	Extra any
+	Err   error
+}
+
+// Pool fans a list of URLs out across Options.Threads workers, governed by a
+// single token-bucket rate limiter shared across all of them.
+type Pool struct {
+	opts   *Options
+	client *resty.Client
+	limit  chan time.Time
+	mu     sync.Mutex
+}
+
+// New builds a Pool whose HTTP client is configured from opts.
+func New(opts *Options, client *resty.Client) *Pool {
+	if opts.Threads <= 0 {
+		opts.Threads = 1
+	}
+
+	client.SetTimeout(opts.Timeout).SetRetryCount(opts.Retries)
+
+	p := &Pool{opts: opts, client: client}
+
+	if opts.RateLimit > 0 {
+		p.limit = make(chan time.Time, opts.RateLimit)
+		ticker := time.NewTicker(time.Second / time.Duration(opts.RateLimit))
+		go func() {
+			for t := range ticker.C {
+				select {
+				case p.limit <- t:
+				default:
+				}
+			}
+		}()
+	}
+
+	return p
+}
+
+// Fetcher performs the actual HTTP round trip for a single URL, plus any
+// other per-target network work (e.g. fingerprinting) a caller wants run
+// concurrently across workers rather than serialized under handle's mutex.
+// That work's result is returned as extra and surfaces on Result.Extra.
+// main wires this to RequestWithClient so the pool stays decoupled from CLI-specific
+// flags.
+type Fetcher func(client *resty.Client, url string) (data []byte, headers http.Header, extra any, err error)
+
+// Run consumes urls across p.opts.Threads workers, invoking fetch for each
+// and handle for every result as soon as it is ready. handle is called under
+// a mutex so callers whose handlers print to stdout (HandleResults et al.)
+// never interleave output from two workers.
+func (p *Pool) Run(urls []string, fetch Fetcher, handle func(Result)) {
+	jobs := make(chan string, len(urls))
+	for _, u := range urls {
+		jobs <- u
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	for i := 0; i < p.opts.Threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url := range jobs {
+				p.wait()
+
+				data, headers, extra, err := fetch(p.client, url)
+				if err != nil {
+					gologger.Error().Msgf("%s [%s]", err, url)
+				}
+
+				p.mu.Lock()
+				handle(Result{URL: url, Data: data, Headers: headers, Extra: extra, Err: err})
+				p.mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// wait blocks until the rate limiter admits the next request. It is a no-op
+// when no rate limit was configured.
+func (p *Pool) wait() {
+	if p.limit == nil {
+		return
+	}
+	<-p.limit
+}
+
+// Note: scanner.SecretsMatch, scanner.EndpointsMatch and scanner.ParameterMatch
+// take their input by value/slice and keep no package-level mutable state, so
+// they are safe to call concurrently from the workers above without locking.
@@ -0,0 +1,67 @@
+package runner
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// TestRunFetchesConcurrently guards against handle's mutex (needed so
+// printing/bookkeeping callers don't interleave output) accidentally
+// serializing the Fetcher itself. Fetch must run outside that lock so slow
+// per-target network I/O (e.g. fingerprinting) still benefits from Threads.
+func TestRunFetchesConcurrently(t *testing.T) {
+	const urlCount = 5
+
+	var inFlight, maxInFlight int32
+
+	urls := make([]string, urlCount)
+	for i := range urls {
+		urls[i] = "http://example.invalid"
+	}
+
+	opts := &Options{Threads: urlCount, Timeout: time.Second}
+	p := New(opts, resty.New())
+
+	fetch := func(client *resty.Client, url string) ([]byte, http.Header, any, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			cur := atomic.LoadInt32(&maxInFlight)
+			if n <= cur || atomic.CompareAndSwapInt32(&maxInFlight, cur, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return nil, nil, nil, nil
+	}
+
+	p.Run(urls, fetch, func(Result) {})
+
+	if maxInFlight < 2 {
+		t.Errorf("expected fetch to run concurrently across workers, max in-flight was %d", maxInFlight)
+	}
+}
+
+// TestRunPassesExtraThrough checks that whatever a Fetcher returns as extra
+// reaches handle unchanged via Result.Extra.
+func TestRunPassesExtraThrough(t *testing.T) {
+	opts := &Options{Threads: 1, Timeout: time.Second}
+	p := New(opts, resty.New())
+
+	fetch := func(client *resty.Client, url string) ([]byte, http.Header, any, error) {
+		return nil, nil, url + "-extra", nil
+	}
+
+	var got string
+	p.Run([]string{"http://example.invalid"}, fetch, func(r Result) {
+		got, _ = r.Extra.(string)
+	})
+
+	if got != "http://example.invalid-extra" {
+		t.Errorf("expected Result.Extra to carry the Fetcher's extra value through, got %q", got)
+	}
+}
@@ -0,0 +1,141 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Part selects which piece of a response a CustomRule is evaluated against.
+const (
+	PartBody   = "body"
+	PartHeader = "header"
+	PartAll    = "all"
+)
+
+// CustomRule is a single user-supplied regex extractor, loaded from a
+// -custom-extract YAML file.
+type CustomRule struct {
+	Name       string
+	Regex      *regexp.Regexp
+	Group      int
+	Severity   string
+	Part       string
+	MinEntropy float64
+}
+
+// customRuleFile is the YAML shape a -custom-extract file is parsed into,
+// before its Regex strings are compiled into CustomRule.Regex.
+type customRuleFile struct {
+	Rules []struct {
+		Name       string  `yaml:"name"`
+		Regex      string  `yaml:"regex"`
+		Group      int     `yaml:"group"`
+		Severity   string  `yaml:"severity"`
+		Part       string  `yaml:"part"`
+		MinEntropy float64 `yaml:"min_entropy"`
+	} `yaml:"rules"`
+}
+
+// LoadCustomRules reads and compiles every rule across the given YAML files.
+// Files are additive, so -custom-extract may be passed more than once.
+func LoadCustomRules(paths []string) ([]CustomRule, error) {
+	var rules []CustomRule
+
+	for _, path := range paths {
+		bin, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read custom rules file %s: %w", path, err)
+		}
+
+		var file customRuleFile
+		if err := yaml.Unmarshal(bin, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse custom rules file %s: %w", path, err)
+		}
+
+		for _, r := range file.Rules {
+			re, err := regexp.Compile(r.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regex for custom rule %q: %w", r.Name, err)
+			}
+
+			part := r.Part
+			if part == "" {
+				part = PartBody
+			}
+
+			rules = append(rules, CustomRule{
+				Name:       r.Name,
+				Regex:      re,
+				Group:      r.Group,
+				Severity:   r.Severity,
+				Part:       part,
+				MinEntropy: r.MinEntropy,
+			})
+		}
+	}
+
+	return rules, nil
+}
+
+// CustomMatch runs every rule against body and/or headers, depending on each
+// rule's Part, and returns results through the same SecretMatched shape
+// SecretsMatch uses so they flow through the existing output pipeline.
+// Matches whose entropy falls below a rule's MinEntropy are discarded.
+func CustomMatch(source string, body []byte, headers string, rules []CustomRule) []SecretMatched {
+	var results []SecretMatched
+
+	for _, rule := range rules {
+		severity := rule.Severity
+		if severity == "" {
+			severity = defaultSeverity
+		}
+
+		if rule.Part == PartBody || rule.Part == PartAll {
+			results = append(results, matchCustomRule(rule, body, severity)...)
+		}
+		if rule.Part == PartHeader || rule.Part == PartAll {
+			results = append(results, matchCustomRule(rule, []byte(headers), severity)...)
+		}
+	}
+
+	return results
+}
+
+func matchCustomRule(rule CustomRule, data []byte, severity string) []SecretMatched {
+	var results []SecretMatched
+
+	for _, loc := range rule.Regex.FindAllSubmatchIndex(data, -1) {
+		start, end := groupBounds(loc, rule.Group)
+		if start < 0 {
+			continue
+		}
+
+		match := string(data[start:end])
+		if rule.MinEntropy > 0 && ShannonEntropy(match) < rule.MinEntropy {
+			continue
+		}
+
+		results = append(results, SecretMatched{
+			Secret:   Secret{Name: rule.Name},
+			Match:    match,
+			Line:     lineNumber(data, start),
+			Context:  snippet(data, start, end),
+			Severity: severity,
+		})
+	}
+
+	return results
+}
+
+// groupBounds returns the [start:end) byte range of submatch group within
+// loc, falling back to the whole match (group 0) when group is out of range.
+func groupBounds(loc []int, group int) (int, int) {
+	idx := group * 2
+	if idx < 0 || idx+1 >= len(loc) || loc[idx] < 0 {
+		return loc[0], loc[1]
+	}
+	return loc[idx], loc[idx+1]
+}
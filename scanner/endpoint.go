@@ -0,0 +1,21 @@
+package scanner
+
+import "regexp"
+
+var endpointRegex = regexp.MustCompile(`(?:"|')((?:https?:)?\/\/[a-zA-Z0-9_\-./?=&%#:]+|\/[a-zA-Z0-9_\-][a-zA-Z0-9_\-./?=&%#]*)(?:"|')`)
+
+// EndpointsMatch extracts absolute URLs and relative paths referenced inside
+// data. Callers are expected to split the result into URLs vs. endpoints
+// based on scheme, as main.Run does.
+func EndpointsMatch(data []byte) []string {
+	var results []string
+
+	for _, match := range endpointRegex.FindAllSubmatch(data, -1) {
+		if len(match) < 2 {
+			continue
+		}
+		results = append(results, string(match[1]))
+	}
+
+	return results
+}
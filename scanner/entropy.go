@@ -0,0 +1,29 @@
+package scanner
+
+import "math"
+
+// ShannonEntropy computes H = -Σ p(c) log2 p(c) over the byte frequencies of
+// s, used to reject low-entropy custom-rule matches (e.g. a regex that
+// happens to match "AAAAAAAAAAAAAAAA") that are almost certainly noise.
+func ShannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	var freq [256]int
+	for i := 0; i < len(s); i++ {
+		freq[s[i]]++
+	}
+
+	var entropy float64
+	length := float64(len(s))
+	for _, count := range freq {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
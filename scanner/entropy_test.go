@@ -0,0 +1,37 @@
+package scanner
+
+import (
+	"math"
+	"testing"
+)
+
+func TestShannonEntropy(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want float64
+	}{
+		{"empty", "", 0},
+		{"single repeated char", "aaaaaaaaaaaaaaaa", 0},
+		{"two equally likely chars", "ab", 1},
+		{"four equally likely chars", "abcd", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ShannonEntropy(tt.s)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ShannonEntropy(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShannonEntropyOrdering(t *testing.T) {
+	low := ShannonEntropy("aaaaaaaaaaaaaaaa")
+	high := ShannonEntropy("kj3$mQ9zP!xR2tBv")
+
+	if !(low < high) {
+		t.Errorf("expected repeated-character string to have lower entropy than high-variance string, got low=%v high=%v", low, high)
+	}
+}
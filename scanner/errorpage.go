@@ -0,0 +1,100 @@
+package scanner
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// minBodyLength is the response size below which a page is treated as
+// suspiciously thin, a common trait of soft-404s and WAF/login walls.
+const minBodyLength = 512
+
+var titleRegex = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// errorPhrase is a single bag-of-phrases signal fed into ErrorPageClassifier's
+// score, weighted by how strongly it implies the page isn't real content.
+type errorPhrase struct {
+	phrase string
+	weight int
+}
+
+var errorPhrases = []errorPhrase{
+	{"404", 2},
+	{"not found", 2},
+	{"forbidden", 2},
+	{"access denied", 2},
+	{"page not found", 3},
+	{"cloudflare", 1},
+	{"captcha", 2},
+	{"checking your browser", 2},
+	{"attention required", 1},
+}
+
+// ErrorPageClassifier scores response bodies on how likely they are to be a
+// soft-404/WAF-block/login-wall page rather than real content, so callers can
+// skip extraction on them instead of polluting results with noise.
+type ErrorPageClassifier struct {
+	whitelist map[string]bool
+}
+
+// NewErrorPageClassifier builds a classifier. fingerprints is an optional
+// list of hex MD5 hashes of known-good bodies that should never be flagged
+// regardless of score.
+func NewErrorPageClassifier(fingerprints []string) *ErrorPageClassifier {
+	whitelist := make(map[string]bool, len(fingerprints))
+	for _, fp := range fingerprints {
+		whitelist[strings.ToLower(fp)] = true
+	}
+
+	return &ErrorPageClassifier{whitelist: whitelist}
+}
+
+// Score returns a non-negative signal strength for body looking like an
+// error page; higher means more likely. It has no fixed scale on its own —
+// callers compare it against their own -error-page-score threshold.
+func (c *ErrorPageClassifier) Score(body []byte) int {
+	score := 0
+	lower := strings.ToLower(string(body))
+
+	for _, p := range errorPhrases {
+		if strings.Contains(lower, p.phrase) {
+			score += p.weight
+		}
+	}
+
+	if title := titleRegex.FindSubmatch(body); title != nil {
+		lowerTitle := strings.ToLower(string(title[1]))
+		for _, p := range errorPhrases {
+			if strings.Contains(lowerTitle, p.phrase) {
+				score += p.weight
+			}
+		}
+	}
+
+	if len(body) < minBodyLength {
+		score++
+	}
+
+	return score
+}
+
+// IsErrorPage reports whether body scores at or above threshold, unless it
+// matches a whitelisted fingerprint.
+func (c *ErrorPageClassifier) IsErrorPage(body []byte, threshold int) bool {
+	if c.whitelisted(body) {
+		return false
+	}
+
+	return c.Score(body) >= threshold
+}
+
+func (c *ErrorPageClassifier) whitelisted(body []byte) bool {
+	if len(c.whitelist) == 0 {
+		return false
+	}
+
+	sum := md5.Sum(body)
+	return c.whitelist[hex.EncodeToString(sum[:])]
+}
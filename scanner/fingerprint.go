@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/SharokhAtaie/extractify/fingerprints"
+	"github.com/go-resty/resty/v2"
+	"github.com/projectdiscovery/gologger"
+)
+
+// Fingerprint is the asset-correlation result for a single target: a
+// Shodan-compatible favicon hash, body hashes, and an optional lightweight
+// TLS fingerprint of the origin.
+type Fingerprint struct {
+	FaviconHash    int32
+	BodyMD5        string
+	BodySHA256     string
+	TLSFingerprint string
+}
+
+// BuildFingerprint computes every fingerprint axis for baseURL. The favicon
+// fetch and TLS handshake are best-effort: a failure on either just leaves
+// that field zero-valued rather than aborting the whole fingerprint, since a
+// target missing a favicon or refusing a second TLS connection is common.
+func BuildFingerprint(client *resty.Client, baseURL string, body []byte, withTLSFingerprint bool) Fingerprint {
+	fp := Fingerprint{}
+
+	favicon, err := fingerprints.Favicon(client, baseURL)
+	if err != nil {
+		gologger.Debug().Msgf("failed to fetch favicon for %s: %v", baseURL, err)
+	} else {
+		fp.FaviconHash = favicon
+	}
+
+	fp.BodyMD5, fp.BodySHA256 = fingerprints.Body(body)
+
+	if withTLSFingerprint {
+		u, err := tlsHostPort(baseURL)
+		if err != nil {
+			gologger.Debug().Msgf("failed to resolve host for TLS fingerprint on %s: %v", baseURL, err)
+			return fp
+		}
+
+		tlsfp, err := fingerprints.TLSFingerprint(u, 5*time.Second)
+		if err != nil {
+			gologger.Debug().Msgf("failed TLS handshake for %s: %v", baseURL, err)
+		} else {
+			fp.TLSFingerprint = tlsfp
+		}
+	}
+
+	return fp
+}
+
+// tlsHostPort turns baseURL into a host:port suitable for tls.DialWithDialer,
+// defaulting to 443 since a TLS fingerprint is only meaningful over TLS.
+func tlsHostPort(baseURL string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Port() != "" {
+		return u.Host, nil
+	}
+
+	return u.Hostname() + ":443", nil
+}
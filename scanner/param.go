@@ -0,0 +1,19 @@
+package scanner
+
+import "regexp"
+
+var paramRegex = regexp.MustCompile(`[?&]([a-zA-Z0-9_\-]+)=`)
+
+// ParameterMatch extracts query-string parameter names referenced in data.
+func ParameterMatch(data string) []string {
+	var results []string
+
+	for _, match := range paramRegex.FindAllStringSubmatch(data, -1) {
+		if len(match) < 2 {
+			continue
+		}
+		results = append(results, match[1])
+	}
+
+	return results
+}
@@ -0,0 +1,80 @@
+package scanner
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// contextRadius is how many bytes of surrounding data to keep on each side
+// of a match for the ContextSnippet shown in structured output.
+const contextRadius = 40
+
+// Secret describes a single regex-based secret signature.
+type Secret struct {
+	Name  string
+	Regex *regexp.Regexp
+}
+
+// SecretMatched is a single secret finding tied back to the source it came
+// from, along with enough location metadata (line number, surrounding
+// bytes) to make it actionable outside of the terminal.
+type SecretMatched struct {
+	Secret   Secret
+	Match    string
+	Line     int
+	Context  string
+	Severity string
+}
+
+// defaultSeverity is what built-in secret signatures are reported at, since
+// SecretsMatch has no per-signature severity of its own.
+const defaultSeverity = "high"
+
+var secrets = []Secret{
+	{Name: "AWS Access Key", Regex: regexp.MustCompile(`AKIA[0-9A-Z]{16}`)},
+	{Name: "Slack Token", Regex: regexp.MustCompile(`xox[baprs]-[0-9A-Za-z-]{10,48}`)},
+	{Name: "Google API Key", Regex: regexp.MustCompile(`AIza[0-9A-Za-z\-_]{35}`)},
+	{Name: "Generic Bearer Token", Regex: regexp.MustCompile(`(?i)bearer\s+[a-z0-9._\-]{20,}`)},
+}
+
+// SecretsMatch runs every known secret signature against data and returns
+// all matches found. Source is kept alongside each call site purely for
+// logging/traceability and does not affect matching.
+func SecretsMatch(source string, data []byte) []SecretMatched {
+	var results []SecretMatched
+
+	for _, secret := range secrets {
+		for _, loc := range secret.Regex.FindAllIndex(data, -1) {
+			start, end := loc[0], loc[1]
+			results = append(results, SecretMatched{
+				Secret:   secret,
+				Match:    string(data[start:end]),
+				Line:     lineNumber(data, start),
+				Context:  snippet(data, start, end),
+				Severity: defaultSeverity,
+			})
+		}
+	}
+
+	return results
+}
+
+// lineNumber returns the 1-indexed line that offset falls on.
+func lineNumber(data []byte, offset int) int {
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// snippet returns the bytes surrounding [start:end], trimmed to
+// contextRadius on each side, for use as a human-readable context_snippet.
+func snippet(data []byte, start, end int) string {
+	lo := start - contextRadius
+	if lo < 0 {
+		lo = 0
+	}
+	hi := end + contextRadius
+	if hi > len(data) {
+		hi = len(data)
+	}
+
+	return string(bytes.TrimSpace(data[lo:hi]))
+}